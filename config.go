@@ -0,0 +1,296 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+	"gopkg.in/yaml.v2"
+)
+
+// keyringService is the OS keyring service name carina stores api-keys under
+const keyringService = "carina"
+
+// Config is the on-disk ~/.carina/config.yaml, holding multiple named
+// profiles so users with several Carina accounts don't have to juggle shells
+type Config struct {
+	CurrentProfile string             `yaml:"current-profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile is one named set of credentials/endpoint/default cluster
+type Profile struct {
+	Username string `yaml:"username,omitempty"`
+	APIKey   string `yaml:"api-key,omitempty"`
+	// APIKeyInKeyring is true once "config set --api-key" has stored the key
+	// in the OS keyring instead of this file; APIKey is left empty in that case
+	APIKeyInKeyring bool   `yaml:"api-key-in-keyring,omitempty"`
+	Endpoint        string `yaml:"endpoint,omitempty"`
+	DefaultCluster  string `yaml:"default-cluster,omitempty"`
+}
+
+// apiKey resolves the profile's API key. If "config set" stored it in the OS
+// keyring, that's the only source of truth, so a keyring that's unreachable
+// here (different host, headless box, no keyring daemon) is reported as an
+// error instead of silently resolving to an empty key.
+func (p Profile) apiKey(name string) (string, error) {
+	if !p.APIKeyInKeyring {
+		return p.APIKey, nil
+	}
+	key, err := keyring.Get(keyringService, name)
+	if err != nil {
+		return "", fmt.Errorf("profile %q's api-key is stored in the OS keyring, which isn't reachable here: %w", name, err)
+	}
+	return key, nil
+}
+
+func configPath() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(u.HomeDir, ".carina", "config.yaml"), nil
+}
+
+func loadConfig() (*Config, error) {
+	cfg := &Config{Profiles: map[string]Profile{}}
+
+	p, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+	return cfg, nil
+}
+
+func saveConfig(cfg *Config) error {
+	p, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0600)
+}
+
+// resolveProfile fills in Username/APIKey/Endpoint from the selected profile
+// wherever flags/env didn't already set them: flags > env > selected-profile > default-profile.
+func (ctx *Context) resolveProfile(cmd *cobra.Command) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := ctx.Profile
+	explicit := name != ""
+	if !explicit {
+		name = cfg.CurrentProfile
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		if explicit {
+			return fmt.Errorf("no such profile %q", name)
+		}
+		// No current-profile selected (or it no longer exists): proceed with
+		// whatever flags/env already gave us.
+		return nil
+	}
+
+	if !cmd.Flags().Changed("username") && ctx.Username == "" {
+		ctx.Username = profile.Username
+	}
+	if !cmd.Flags().Changed("api-key") && ctx.APIKey == "" {
+		key, err := profile.apiKey(name)
+		if err != nil {
+			return err
+		}
+		ctx.APIKey = key
+	}
+	if !cmd.Flags().Changed("endpoint") && profile.Endpoint != "" {
+		ctx.Endpoint = profile.Endpoint
+	}
+	ctx.DefaultCluster = profile.DefaultCluster
+	return nil
+}
+
+// newConfigCommand builds the "config" management command group
+func newConfigCommand(ctx *Context) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:         "config",
+		Short:       "Manage carina CLI profiles (~/.carina/config.yaml)",
+		Annotations: map[string]string{managementAnnotation: "true"},
+	}
+	configCmd.AddCommand(
+		newConfigSetCommand(),
+		newConfigGetCommand(),
+		newConfigUseContextCommand(),
+		newConfigListContextsCommand(),
+	)
+	return configCmd
+}
+
+func newConfigSetCommand() *cobra.Command {
+	var username, apiKey, endpoint, defaultCluster string
+
+	cmd := &cobra.Command{
+		Use:   "set <profile>",
+		Short: "Create or update a named profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			profile := cfg.Profiles[name]
+
+			if cmd.Flags().Changed("username") {
+				profile.Username = username
+			}
+			if cmd.Flags().Changed("endpoint") {
+				profile.Endpoint = endpoint
+			}
+			if cmd.Flags().Changed("default-cluster") {
+				profile.DefaultCluster = defaultCluster
+			}
+			if cmd.Flags().Changed("api-key") {
+				// Prefer the OS keyring when available, falling back to the config file
+				if keyringErr := keyring.Set(keyringService, name, apiKey); keyringErr == nil {
+					profile.APIKey = ""
+					profile.APIKeyInKeyring = true
+				} else {
+					profile.APIKey = apiKey
+					profile.APIKeyInKeyring = false
+				}
+			}
+
+			cfg.Profiles[name] = profile
+			if cfg.CurrentProfile == "" {
+				cfg.CurrentProfile = name
+			}
+			return saveConfig(cfg)
+		},
+	}
+	cmd.Flags().StringVar(&username, "username", "", "Rackspace username")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "Rackspace API key, stored in the OS keyring when available")
+	cmd.Flags().StringVar(&endpoint, "endpoint", "", "Carina API endpoint")
+	cmd.Flags().StringVar(&defaultCluster, "default-cluster", "", "cluster name to assume when one isn't given")
+	return cmd
+}
+
+func newConfigGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <profile>",
+		Short: "Show a named profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			profile, ok := cfg.Profiles[name]
+			if !ok {
+				return fmt.Errorf("no such profile %q", name)
+			}
+
+			key, err := profile.apiKey(name)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "username\t%s\n", profile.Username)
+			fmt.Fprintf(os.Stdout, "api-key\t%s\n", maskAPIKey(key))
+			fmt.Fprintf(os.Stdout, "endpoint\t%s\n", profile.Endpoint)
+			fmt.Fprintf(os.Stdout, "default-cluster\t%s\n", profile.DefaultCluster)
+			return nil
+		},
+	}
+}
+
+func newConfigUseContextCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context <profile>",
+		Short: "Set the default profile used when --profile isn't given",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Profiles[name]; !ok {
+				return fmt.Errorf("no such profile %q", name)
+			}
+
+			cfg.CurrentProfile = name
+			return saveConfig(cfg)
+		},
+	}
+}
+
+func newConfigListContextsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-contexts",
+		Short: "List known profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(cfg.Profiles))
+			for name := range cfg.Profiles {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				marker := " "
+				if name == cfg.CurrentProfile {
+					marker = "*"
+				}
+				fmt.Fprintf(os.Stdout, "%s %s\n", marker, name)
+			}
+			return nil
+		},
+	}
+}
+
+func maskAPIKey(key string) string {
+	if len(key) <= 4 {
+		return key
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// usageTemplate renders "Management Commands" (command groups like cluster,
+// swarm, credentials) and "Commands" (flat operation shortcuts) as separate
+// sections, the way Docker's root command does.
+const usageTemplate = `Usage:{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
+
+Aliases:
+  {{.NameAndAliases}}{{end}}{{if .HasExample}}
+
+Examples:
+{{.Example}}{{end}}{{if hasManagementSubCommands .}}
+
+Management Commands:{{range managementSubCommands .}}
+  {{rpad .Name .NamePadding}} {{.Short}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+Commands:{{range operationSubCommands .}}
+  {{rpad .Name .NamePadding}} {{.Short}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+Flags:
+{{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+Global Flags:
+{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
+
+Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
+`
+
+// isManagementCommand reports whether cmd is a command group like "cluster"
+// or "swarm", as opposed to a flat back-compat operation shortcut
+func isManagementCommand(cmd *cobra.Command) bool {
+	return cmd.Annotations[managementAnnotation] == "true"
+}
+
+func managementSubCommands(cmd *cobra.Command) []*cobra.Command {
+	var cmds []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() && isManagementCommand(c) {
+			cmds = append(cmds, c)
+		}
+	}
+	return cmds
+}
+
+func operationSubCommands(cmd *cobra.Command) []*cobra.Command {
+	var cmds []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() && !isManagementCommand(c) {
+			cmds = append(cmds, c)
+		}
+	}
+	return cmds
+}
+
+func hasManagementSubCommands(cmd *cobra.Command) bool {
+	return len(managementSubCommands(cmd)) > 0
+}
+
+// setupHelp installs the management/operation usage template and the
+// hidden --bash-completion flag, replacing kingpin's equivalent behavior
+// with Cobra's built-in completion generator.
+func setupHelp(root *cobra.Command) {
+	cobra.AddTemplateFunc("hasManagementSubCommands", hasManagementSubCommands)
+	cobra.AddTemplateFunc("managementSubCommands", managementSubCommands)
+	cobra.AddTemplateFunc("operationSubCommands", operationSubCommands)
+	root.SetUsageTemplate(usageTemplate)
+
+	var bashCompletion bool
+	root.PersistentFlags().BoolVar(&bashCompletion, "bash-completion", false, "Generate bash completion")
+	_ = root.PersistentFlags().MarkHidden("bash-completion")
+
+	// Cobra skips PersistentPreRunE on a command that isn't runnable, so give
+	// the root a RunE that shows help; "carina --bash-completion" then works.
+	if !root.Runnable() {
+		root.RunE = func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		}
+	}
+
+	originalPreRunE := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if bashCompletion {
+			if err := root.GenBashCompletion(os.Stdout); err != nil {
+				return err
+			}
+			os.Exit(0)
+		}
+		if originalPreRunE != nil {
+			return originalPreRunE(cmd, args)
+		}
+		return nil
+	}
+}
@@ -1,41 +1,66 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"path"
 	"strconv"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
-	"gopkg.in/alecthomas/kingpin.v2"
+	"github.com/spf13/cobra"
 
+	"github.com/rackerlabs/carina/pkg/rollout"
 	"github.com/rackerlabs/carina/version"
 	"github.com/rackerlabs/libcarina"
 )
 
+// managementAnnotation marks a command as a Docker-style "management command"
+// (a group like "cluster"/"swarm"/"credentials") for the usage template, as
+// opposed to the flat "operation commands" kept around for back-compat.
+const managementAnnotation = "carina_management"
+
 // Application is, our, well, application
 type Application struct {
 	*Context
-	*kingpin.Application
+	Root *cobra.Command
 }
 
 // Command is a command needing a ClusterClient
 type Command struct {
 	*Context
-	*kingpin.CmdClause
 }
 
 // Context context for the  App
 type Context struct {
 	ClusterClient *libcarina.ClusterClient
 	TabWriter     *tabwriter.Writer
+	Printer       Printer
+	Format        string
 	Username      string
 	APIKey        string
 	Endpoint      string
+	// Profile selects a named profile from ~/.carina/config.yaml, falling
+	// back to the config file's current-profile when unset
+	Profile string
+	// DefaultCluster is the resolved profile's default-cluster, if any; set by
+	// resolveProfile and used to fill in an omitted cluster-name argument
+	DefaultCluster string
+
+	// Ctx is cancelled on SIGINT/SIGTERM or when Timeout elapses
+	Ctx    context.Context
+	Cancel context.CancelFunc
+
+	// Timeout bounds the whole command, zero disables it
+	Timeout time.Duration
+	// WaitTimeout bounds how long a --wait flag will block, zero disables it
+	WaitTimeout time.Duration
 }
 
 // ClusterCommand is a Command with a ClusterName set
@@ -76,64 +101,109 @@ type GrowCommand struct {
 	Nodes int
 }
 
-// New creates a new Application
-func New() *Application {
+// ShrinkCommand keeps context about the number of nodes to scale down by
+type ShrinkCommand struct {
+	*ClusterCommand
+	Nodes int
+}
 
-	app := kingpin.New("carina", "command line interface to launch and work with Docker Swarm clusters")
-	app.Version(VersionString())
+// ScaleCommand keeps context about the target absolute node count
+type ScaleCommand struct {
+	*WaitClusterCommand
+	Nodes int
+}
 
-	cap := new(Application)
+// WaitCommand keeps context about the standalone "wait" command
+type WaitCommand struct {
+	*ClusterCommand
+	// For is the terminal state to wait for: "active" or "deleted"
+	For string
+}
+
+// New creates a new Application
+func New() *Application {
 	ctx := new(Context)
 
-	cap.Application = app
+	root := &cobra.Command{
+		Use:           "carina",
+		Short:         "command line interface to launch and work with Docker Swarm clusters",
+		Version:       VersionString(),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
 
-	cap.Context = ctx
+	cap := &Application{Context: ctx, Root: root}
 
-	cap.Flag("username", "Rackspace username - can also set env var RACKSPACE_USERNAME").OverrideDefaultFromEnvar("RACKSPACE_USERNAME").StringVar(&ctx.Username)
-	cap.Flag("api-key", "Rackspace API Key - can also set env var RACKSPACE_APIKEY").OverrideDefaultFromEnvar("RACKSPACE_APIKEY").PlaceHolder("RACKSPACE_APIKEY").StringVar(&ctx.APIKey)
-	cap.Flag("endpoint", "Carina API endpoint").Default(libcarina.BetaEndpoint).StringVar(&ctx.Endpoint)
+	flags := root.PersistentFlags()
+	flags.StringVar(&ctx.Username, "username", os.Getenv("RACKSPACE_USERNAME"), "Rackspace username - can also set env var RACKSPACE_USERNAME")
+	flags.StringVar(&ctx.APIKey, "api-key", os.Getenv("RACKSPACE_APIKEY"), "Rackspace API Key - can also set env var RACKSPACE_APIKEY")
+	flags.StringVar(&ctx.Endpoint, "endpoint", libcarina.BetaEndpoint, "Carina API endpoint")
+	flags.DurationVar(&ctx.Timeout, "timeout", 0, "overall timeout for the command, 0 to disable")
+	flags.DurationVar(&ctx.WaitTimeout, "wait-timeout", 30*time.Minute, "timeout when waiting with --wait, 0 to disable")
+	flags.StringVarP(&ctx.Format, "format", "f", "table", "output format: table, json, yaml, template=..., jsonpath=...")
+	flags.StringVar(&ctx.Profile, "profile", "", "named profile from ~/.carina/config.yaml to use, defaults to its current-profile")
 
 	writer := new(tabwriter.Writer)
 	writer.Init(os.Stdout, 0, 8, 1, '\t', 0)
-
-	// Make sure the tabwriter gets flushed at the end
-	app.Terminate(func(code int) {
-		// Squish any errors from flush, since we're terminating the app anyway
-		_ = ctx.TabWriter.Flush()
-		os.Exit(code)
-	})
-
-	cap.Flag("bash-completion", "Generate bash completion").Action(cap.generateBashCompletion).Hidden()
-
 	ctx.TabWriter = writer
+	// Default Printer in case help/errors fire before a command's PreRunE runs
+	ctx.Printer = &tablePrinter{w: writer}
 
-	createCommand := new(CreateCommand)
-	createCommand.WaitClusterCommand = cap.NewWaitClusterCommand(ctx, "create", "Create a swarm cluster")
-	createCommand.Flag("nodes", "number of nodes for the initial cluster").Default("1").IntVar(&createCommand.Nodes)
-	createCommand.Flag("autoscale", "whether autoscale is on or off").BoolVar(&createCommand.AutoScale)
-	createCommand.Action(createCommand.Create)
-
-	getCommand := cap.NewClusterCommand(ctx, "get", "Get information about a swarm cluster")
-	getCommand.Action(getCommand.Get)
-
-	listCommand := cap.NewCommand(ctx, "list", "List swarm clusters")
-	listCommand.Action(listCommand.List)
+	setupHelp(root)
 
-	credentialsCommand := new(CredentialsCommand)
-	credentialsCommand.ClusterCommand = cap.NewClusterCommand(ctx, "credentials", "Download credentials for a swarm cluster")
-	credentialsCommand.Flag("path", "path to write credentials out to").PlaceHolder("<cluster-name>").StringVar(&credentialsCommand.Path)
-	credentialsCommand.Action(credentialsCommand.Download)
-
-	growCommand := new(GrowCommand)
-	growCommand.ClusterCommand = cap.NewClusterCommand(ctx, "grow", "Grow a cluster by the requested number of nodes")
-	growCommand.Flag("nodes", "number of nodes to increase the cluster by").Required().IntVar(&growCommand.Nodes)
-	growCommand.Action(growCommand.Grow)
-
-	rebuildCommand := cap.NewWaitClusterCommand(ctx, "rebuild", "Rebuild a swarm cluster")
-	rebuildCommand.Action(rebuildCommand.Rebuild)
+	clusterCmd := &cobra.Command{
+		Use:         "cluster",
+		Short:       "Manage swarm clusters",
+		Annotations: map[string]string{managementAnnotation: "true"},
+	}
+	clusterCmd.AddCommand(
+		newGetCommand(ctx),
+		newListCommand(ctx),
+		newCreateCommand(ctx),
+		newGrowCommand(ctx),
+		newShrinkCommand(ctx),
+		newScaleCommand(ctx),
+		newRebuildCommand(ctx),
+		newDeleteCommand(ctx),
+		newWaitCommand(ctx),
+	)
+	root.AddCommand(clusterCmd)
+
+	credentialsCmd := &cobra.Command{
+		Use:         "credentials",
+		Short:       "Download credentials for a swarm cluster",
+		Annotations: map[string]string{managementAnnotation: "true"},
+	}
+	credentialsCmd.AddCommand(newCredentialsDownloadCommand(ctx))
+	root.AddCommand(credentialsCmd)
 
-	deleteCommand := cap.NewClusterCommand(ctx, "delete", "Delete a swarm cluster")
-	deleteCommand.Action(deleteCommand.Delete)
+	swarmCmd := &cobra.Command{
+		Use:         "swarm",
+		Short:       "Query a cluster's Docker Swarm directly using downloaded credentials",
+		Annotations: map[string]string{managementAnnotation: "true"},
+	}
+	swarmCmd.AddCommand(
+		newSwarmCommand(ctx, "nodes", "List the nodes in a swarm cluster", (*SwarmCommand).Nodes),
+		newSwarmCommand(ctx, "services", "List the services running on a swarm cluster", (*SwarmCommand).Services),
+		newSwarmCommand(ctx, "ps", "List the tasks running on a swarm cluster", (*SwarmCommand).Ps),
+	)
+	root.AddCommand(swarmCmd)
+	root.AddCommand(newConfigCommand(ctx))
+
+	// Operation commands: flat top-level shortcuts kept for back-compat with
+	// the pre-Cobra CLI, mirroring their "cluster"/"credentials" equivalents.
+	root.AddCommand(
+		newGetCommand(ctx),
+		newListCommand(ctx),
+		newCreateCommand(ctx),
+		newGrowCommand(ctx),
+		newShrinkCommand(ctx),
+		newScaleCommand(ctx),
+		newRebuildCommand(ctx),
+		newDeleteCommand(ctx),
+		newCredentialsDownloadCommand(ctx),
+		newWaitCommand(ctx),
+	)
 
 	return cap
 }
@@ -146,100 +216,343 @@ func VersionString() string {
 	return s
 }
 
-// NewCommand creates a command wrapped with carina.Context
-func (app *Application) NewCommand(ctx *Context, name, help string) *Command {
-	carina := new(Command)
-	carina.Context = ctx
-	carina.CmdClause = app.Command(name, help)
-	carina.PreAction(carina.Auth)
-	return carina
+// newCommand builds a bare Command wired up with PreRunE (Auth) for leaves
+// that take no cluster-name argument, e.g. "list"
+func newCommand(ctx *Context, use, short string) (*Command, *cobra.Command) {
+	carina := &Command{Context: ctx}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Args:    cobra.NoArgs,
+		PreRunE: carina.Auth,
+	}
+	return carina, cmd
+}
+
+// newClusterCommand builds a ClusterCommand that binds args[0] to
+// ClusterName before running Auth, falling back to the active profile's
+// default-cluster when the argument is omitted
+func newClusterCommand(ctx *Context, use, short string) (*ClusterCommand, *cobra.Command) {
+	cc := &ClusterCommand{Command: &Command{Context: ctx}}
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cc.ClusterName = args[0]
+			}
+			if err := cc.Auth(cmd, args); err != nil {
+				return err
+			}
+			if cc.ClusterName == "" {
+				cc.ClusterName = cc.DefaultCluster
+			}
+			if cc.ClusterName == "" {
+				return errors.New("a cluster name is required: pass it as an argument, or set default-cluster on the active profile")
+			}
+			return nil
+		},
+	}
+	return cc, cmd
+}
+
+// newWaitClusterCommand is a ClusterCommand that also exposes --wait
+func newWaitClusterCommand(ctx *Context, use, short string) (*WaitClusterCommand, *cobra.Command) {
+	cc, cmd := newClusterCommand(ctx, use, short)
+	wcc := &WaitClusterCommand{ClusterCommand: cc}
+	cmd.Flags().BoolVar(&wcc.Wait, "wait", false, "wait for swarm cluster to come online (or error)")
+	return wcc, cmd
 }
 
-// NewClusterCommand is a command that uses a cluster name
-func (app *Application) NewClusterCommand(ctx *Context, name, help string) *ClusterCommand {
-	cc := new(ClusterCommand)
-	cc.Command = app.NewCommand(ctx, name, help)
-	cc.Arg("cluster-name", "name of the cluster").Required().StringVar(&cc.ClusterName)
-	return cc
+func newGetCommand(ctx *Context) *cobra.Command {
+	cc, cmd := newClusterCommand(ctx, "get [<cluster-name>]", "Get information about a swarm cluster")
+	cmd.RunE = cc.Get
+	return cmd
 }
 
-// NewWaitClusterCommand is a command that uses a cluster name and allows the
-// user to wait for a cluster state
-func (app *Application) NewWaitClusterCommand(ctx *Context, name, help string) *WaitClusterCommand {
-	wcc := new(WaitClusterCommand)
-	wcc.ClusterCommand = app.NewClusterCommand(ctx, name, help)
-	wcc.Flag("wait", "wait for swarm cluster to come online (or error)").BoolVar(&wcc.Wait)
-	return wcc
+func newListCommand(ctx *Context) *cobra.Command {
+	c, cmd := newCommand(ctx, "list", "List swarm clusters")
+	cmd.RunE = c.List
+	return cmd
 }
 
-// Auth does the authentication
-func (carina *Command) Auth(pc *kingpin.ParseContext) (err error) {
-	carina.ClusterClient, err = libcarina.NewClusterClient(carina.Endpoint, carina.Username, carina.APIKey)
-	return err
+func newDeleteCommand(ctx *Context) *cobra.Command {
+	cc, cmd := newClusterCommand(ctx, "delete [<cluster-name>]", "Delete a swarm cluster")
+	cmd.RunE = cc.Delete
+	return cmd
 }
 
-// List the current swarm clusters
-func (carina *Command) List(pc *kingpin.ParseContext) (err error) {
-	clusterList, err := carina.ClusterClient.List()
+func newGrowCommand(ctx *Context) *cobra.Command {
+	cc, cmd := newClusterCommand(ctx, "grow [<cluster-name>]", "Grow a cluster by the requested number of nodes")
+	growCommand := &GrowCommand{ClusterCommand: cc}
+	cmd.Flags().IntVar(&growCommand.Nodes, "nodes", 0, "number of nodes to increase the cluster by")
+	_ = cmd.MarkFlagRequired("nodes")
+	cmd.RunE = growCommand.Grow
+	return cmd
+}
+
+func newShrinkCommand(ctx *Context) *cobra.Command {
+	cc, cmd := newClusterCommand(ctx, "shrink [<cluster-name>]", "Shrink a cluster by the requested number of nodes")
+	shrinkCommand := &ShrinkCommand{ClusterCommand: cc}
+	cmd.Flags().IntVar(&shrinkCommand.Nodes, "nodes", 0, "number of nodes to decrease the cluster by")
+	_ = cmd.MarkFlagRequired("nodes")
+	cmd.RunE = shrinkCommand.Shrink
+	return cmd
+}
+
+func newScaleCommand(ctx *Context) *cobra.Command {
+	wcc, cmd := newWaitClusterCommand(ctx, "scale [<cluster-name>]", "Scale a cluster to an absolute number of nodes")
+	scaleCommand := &ScaleCommand{WaitClusterCommand: wcc}
+	cmd.Flags().IntVar(&scaleCommand.Nodes, "nodes", 0, "desired total number of nodes")
+	_ = cmd.MarkFlagRequired("nodes")
+	cmd.RunE = scaleCommand.Scale
+	return cmd
+}
+
+func newRebuildCommand(ctx *Context) *cobra.Command {
+	wcc, cmd := newWaitClusterCommand(ctx, "rebuild [<cluster-name>]", "Rebuild a swarm cluster")
+	cmd.RunE = wcc.Rebuild
+	return cmd
+}
+
+func newWaitCommand(ctx *Context) *cobra.Command {
+	cc, cmd := newClusterCommand(ctx, "wait [<cluster-name>]", "Wait for a cluster to reach a terminal status")
+	waitCommand := &WaitCommand{ClusterCommand: cc, For: "active"}
+	cmd.Flags().StringVar(&waitCommand.For, "for", "active", `status to wait for: "active" or "deleted"`)
+	cmd.RunE = waitCommand.Wait
+	return cmd
+}
+
+func newCreateCommand(ctx *Context) *cobra.Command {
+	wcc, cmd := newWaitClusterCommand(ctx, "create [<cluster-name>]", "Create a swarm cluster")
+	createCommand := &CreateCommand{WaitClusterCommand: wcc}
+	cmd.Flags().IntVar(&createCommand.Nodes, "nodes", 1, "number of nodes for the initial cluster")
+	cmd.Flags().BoolVar(&createCommand.AutoScale, "autoscale", false, "whether autoscale is on or off")
+	cmd.RunE = createCommand.Create
+	return cmd
+}
+
+func newCredentialsDownloadCommand(ctx *Context) *cobra.Command {
+	cc, cmd := newClusterCommand(ctx, "credentials [<cluster-name>]", "Download credentials for a swarm cluster")
+	credentialsCommand := &CredentialsCommand{ClusterCommand: cc}
+	cmd.Flags().StringVar(&credentialsCommand.Path, "path", "", "path to write credentials out to (defaults to <cluster-name>)")
+	cmd.RunE = credentialsCommand.Download
+	return cmd
+}
+
+// Auth does the authentication and sets up a cancellable Context for the command
+func (carina *Command) Auth(cmd *cobra.Command, args []string) (err error) {
+	if err := carina.resolveProfile(cmd); err != nil {
+		return err
+	}
+
+	carina.ClusterClient, err = libcarina.NewClusterClient(carina.Endpoint, carina.Username, carina.APIKey)
 	if err != nil {
 		return err
 	}
 
-	err = writeClusterHeader(carina.TabWriter)
+	carina.Printer, err = NewPrinter(carina.Format, os.Stdout)
 	if err != nil {
 		return err
 	}
 
-	for _, cluster := range clusterList {
-		err = writeCluster(carina.TabWriter, &cluster)
-		if err != nil {
-			return err
+	carina.setupCancellation()
+	return nil
+}
+
+// setupCancellation wires Ctx/Cancel so in-flight waits can be abandoned on
+// Ctrl-C or bounded by Timeout. Shared by commands that don't all go through Auth.
+func (ctx *Context) setupCancellation() {
+	c := context.Background()
+	if ctx.Timeout > 0 {
+		c, ctx.Cancel = context.WithTimeout(c, ctx.Timeout)
+	} else {
+		c, ctx.Cancel = context.WithCancel(c)
+	}
+	ctx.Ctx = c
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sigCh:
+			ctx.Cancel()
+		case <-c.Done():
 		}
+		signal.Stop(sigCh)
+	}()
+}
+
+// runCancelable runs fn in a goroutine and returns its error, or ctx.Err() as
+// soon as ctx is done, whichever happens first. libcarina has no *WithContext
+// variants of its calls, so this is how --timeout and Ctrl-C get to cut a
+// command short instead of only bounding the --wait polling loop after it:
+// fn may keep running against the API in the background, but the command
+// itself stops waiting on it.
+func runCancelable(ctx context.Context, fn func() error) error {
+	ch := make(chan error, 1)
+	go func() { ch <- fn() }()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-ch:
+		return err
 	}
-	err = carina.TabWriter.Flush()
-	return err
+}
+
+// List the current swarm clusters
+func (carina *Command) List(cmd *cobra.Command, args []string) (err error) {
+	var clusterList []libcarina.Cluster
+	err = runCancelable(carina.Ctx, func() (err error) {
+		clusterList, err = carina.ClusterClient.List()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = carina.Printer.PrintClusters(clusterList)
+	if err != nil {
+		return err
+	}
+	return carina.Printer.Flush()
 }
 
 type clusterOp func(clusterName string) (*libcarina.Cluster, error)
 
 // Does an func against a cluster then returns the new cluster representation
 func (carina *ClusterCommand) clusterApply(op clusterOp) (err error) {
-	cluster, err := op(carina.ClusterName)
+	var cluster *libcarina.Cluster
+	err = runCancelable(carina.Ctx, func() (err error) {
+		cluster, err = op(carina.ClusterName)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	err = writeCluster(carina.TabWriter, cluster)
+	err = carina.Printer.PrintCluster(cluster)
 	if err != nil {
 		return err
 	}
-	return carina.TabWriter.Flush()
+	return carina.Printer.Flush()
 }
 
 // Get an individual cluster
-func (carina *ClusterCommand) Get(pc *kingpin.ParseContext) (err error) {
+func (carina *ClusterCommand) Get(cmd *cobra.Command, args []string) (err error) {
 	return carina.clusterApply(carina.ClusterClient.Get)
 }
 
 // Delete a cluster
-func (carina *ClusterCommand) Delete(pc *kingpin.ParseContext) (err error) {
+func (carina *ClusterCommand) Delete(cmd *cobra.Command, args []string) (err error) {
 	return carina.clusterApply(carina.ClusterClient.Delete)
 }
 
+// isNotFoundError reports whether err looks like a "no such cluster" response.
+// libcarina has no typed not-found error, so this sniffs the error text -
+// anything else (network blips, auth failures, rate limiting) is left as a
+// real error instead of being mistaken for the cluster having been deleted.
+func isNotFoundError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "404")
+}
+
+// classifyDeletedStatus reports success once the cluster has reached the
+// synthetic "deleted" state produced by WaitCommand.Wait's get func
+func classifyDeletedStatus(status string) rollout.Status {
+	if status == "deleted" {
+		return rollout.Success
+	}
+	return rollout.Pending
+}
+
+// Wait polls until the cluster reaches --for ("active" or "deleted")
+func (carina *WaitCommand) Wait(cmd *cobra.Command, args []string) error {
+	ctx := carina.Ctx
+	if carina.WaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, carina.WaitTimeout)
+		defer cancel()
+	}
+
+	classify := classifyClusterStatus
+	if carina.For == "deleted" {
+		classify = classifyDeletedStatus
+	} else if carina.For != "active" {
+		return fmt.Errorf(`--for must be "active" or "deleted", got %q`, carina.For)
+	}
+
+	w := rollout.NewWaiter(func(ctx context.Context) (string, error) {
+		var cluster *libcarina.Cluster
+		err := runCancelable(ctx, func() (err error) {
+			cluster, err = carina.ClusterClient.Get(carina.ClusterName)
+			return err
+		})
+		if err != nil {
+			if carina.For == "deleted" && isNotFoundError(err) {
+				return "deleted", nil
+			}
+			return "", err
+		}
+		return cluster.Status, nil
+	}, classify)
+
+	progress := make(chan rollout.Progress, 1)
+	w.Progress = progress
+	done := printProgress(progress)
+
+	state, err := w.Wait(ctx)
+	close(progress)
+	<-done
+
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, state)
+	return nil
+}
+
 // Grow increases the size of the given cluster
-func (carina *GrowCommand) Grow(pc *kingpin.ParseContext) (err error) {
+func (carina *GrowCommand) Grow(cmd *cobra.Command, args []string) (err error) {
 	return carina.clusterApply(func(clusterName string) (*libcarina.Cluster, error) {
 		return carina.ClusterClient.Grow(clusterName, carina.Nodes)
 	})
 }
 
+// Shrink decreases the size of the given cluster
+func (carina *ShrinkCommand) Shrink(cmd *cobra.Command, args []string) (err error) {
+	return carina.clusterApply(func(clusterName string) (*libcarina.Cluster, error) {
+		if carina.Nodes < 1 {
+			return nil, errors.New("nodes must be >= 1")
+		}
+		return carina.ClusterClient.Grow(clusterName, -carina.Nodes)
+	})
+}
+
+// Scale grows or shrinks a cluster to the requested absolute number of nodes
+func (carina *ScaleCommand) Scale(cmd *cobra.Command, args []string) (err error) {
+	return carina.clusterApplyWait(func(clusterName string) (*libcarina.Cluster, error) {
+		cluster, err := carina.ClusterClient.Get(clusterName)
+		if err != nil {
+			return nil, err
+		}
+
+		delta := carina.Nodes - int(cluster.Nodes.Int64())
+		if delta == 0 {
+			return nil, fmt.Errorf("cluster %q already has %d nodes", clusterName, carina.Nodes)
+		}
+		return carina.ClusterClient.Grow(clusterName, delta)
+	})
+}
+
 // Rebuild nukes your cluster and builds it over again
-func (carina *WaitClusterCommand) Rebuild(pc *kingpin.ParseContext) (err error) {
+func (carina *WaitClusterCommand) Rebuild(cmd *cobra.Command, args []string) (err error) {
 	return carina.clusterApplyWait(carina.ClusterClient.Rebuild)
 }
 
 const startupFudgeFactor = 40 * time.Second
-const waitBetween = 10 * time.Second
 
 // Cluster status when new
 const StatusNew = "new"
@@ -250,36 +563,124 @@ const StatusBuilding = "building"
 // Cluster status when rebuilding swarm
 const StatusRebuildingSwarm = "rebuilding-swarm"
 
+// Cluster status once it has failed to build or rebuild
+const StatusError = "error"
+
+// Cluster status once it's up and serving
+const StatusActive = "active"
+
+// pendingClusterStatuses are the statuses a cluster passes through before
+// reaching a terminal one
+var pendingClusterStatuses = map[string]bool{
+	StatusNew:             true,
+	StatusBuilding:        true,
+	StatusRebuildingSwarm: true,
+}
+
+// successClusterStatuses are the terminal statuses a cluster reaches on success
+var successClusterStatuses = map[string]bool{
+	StatusActive: true,
+}
+
+// classifyClusterStatus treats StatusError as a failure terminus,
+// successClusterStatuses as success, and anything else - including an
+// unrecognized status - as still pending, bounded by the caller's
+// WaitTimeout/--timeout rather than assumed done
+func classifyClusterStatus(status string) rollout.Status {
+	switch {
+	case pendingClusterStatuses[status]:
+		return rollout.Pending
+	case successClusterStatuses[status]:
+		return rollout.Success
+	case status == StatusError:
+		return rollout.Failure
+	default:
+		// An unrecognized status keeps us polling rather than assuming
+		// success; WaitTimeout/--timeout still bounds how long that lasts.
+		return rollout.Pending
+	}
+}
+
 // Does an func against a cluster then returns the new cluster representation
 func (carina *WaitClusterCommand) clusterApplyWait(op clusterOp) (err error) {
-	cluster, err := op(carina.ClusterName)
+	var cluster *libcarina.Cluster
+	err = runCancelable(carina.Ctx, func() (err error) {
+		cluster, err = op(carina.ClusterName)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
 	if carina.Wait {
-		time.Sleep(startupFudgeFactor)
-		// Transitions past point of "new" or "building" are assumed to be states we
-		// can stop on.
-		for cluster.Status == StatusNew || cluster.Status == StatusBuilding || cluster.Status == StatusRebuildingSwarm {
-			time.Sleep(waitBetween)
-			cluster, err = carina.ClusterClient.Get(carina.ClusterName)
-			if err != nil {
-				break
-			}
+		cluster, err = carina.waitForCluster(cluster)
+		if err != nil {
+			return err
 		}
 	}
 
+	err = carina.Printer.PrintCluster(cluster)
 	if err != nil {
 		return err
 	}
+	return carina.Printer.Flush()
+}
 
-	err = writeCluster(carina.TabWriter, cluster)
-	if err != nil {
-		return err
+// waitForCluster polls the cluster via pkg/rollout until it reaches a
+// terminal status, the command's Context is cancelled (Ctrl-C), or
+// WaitTimeout elapses, printing progress to stderr as it goes.
+func (carina *WaitClusterCommand) waitForCluster(cluster *libcarina.Cluster) (*libcarina.Cluster, error) {
+	ctx := carina.Ctx
+	if carina.WaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, carina.WaitTimeout)
+		defer cancel()
+	}
+
+	select {
+	case <-time.After(startupFudgeFactor):
+	case <-ctx.Done():
+		return cluster, ctx.Err()
 	}
-	return carina.TabWriter.Flush()
+
+	w := rollout.NewWaiter(func(ctx context.Context) (string, error) {
+		err := runCancelable(ctx, func() (err error) {
+			cluster, err = carina.ClusterClient.Get(carina.ClusterName)
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+		return cluster.Status, nil
+	}, classifyClusterStatus)
+
+	progress := make(chan rollout.Progress, 1)
+	w.Progress = progress
+	done := printProgress(progress)
+
+	_, err := w.Wait(ctx)
+	close(progress)
+	<-done
+
+	return cluster, err
+}
+
+// printProgress renders a Waiter's Progress channel to stderr, one line per
+// update, until the channel is closed. It returns a channel that's closed
+// once rendering has drained, so callers can wait for it to finish.
+func printProgress(progress <-chan rollout.Progress) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for p := range progress {
+			fmt.Fprintf(os.Stderr, "...%s (%s elapsed)\n", p.State, p.Elapsed.Round(time.Second))
+		}
+	}()
+	return done
 }
 
 // Create a cluster
-func (carina *CreateCommand) Create(pc *kingpin.ParseContext) (err error) {
+func (carina *CreateCommand) Create(cmd *cobra.Command, args []string) (err error) {
 	return carina.clusterApplyWait(func(clusterName string) (*libcarina.Cluster, error) {
 		if carina.Nodes < 1 {
 			return nil, errors.New("nodes must be >= 1")
@@ -296,8 +697,12 @@ func (carina *CreateCommand) Create(pc *kingpin.ParseContext) (err error) {
 }
 
 // Download credentials for a cluster
-func (carina *CredentialsCommand) Download(pc *kingpin.ParseContext) (err error) {
-	credentials, err := carina.ClusterClient.GetCredentials(carina.ClusterName)
+func (carina *CredentialsCommand) Download(cmd *cobra.Command, args []string) (err error) {
+	var credentials *libcarina.Credentials
+	err = runCancelable(carina.Ctx, func() (err error) {
+		credentials, err = carina.ClusterClient.GetCredentials(carina.ClusterName)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -316,18 +721,17 @@ func (carina *CredentialsCommand) Download(pc *kingpin.ParseContext) (err error)
 		return err
 	}
 
-	err = writeCredentials(carina.TabWriter, credentials, p)
+	err = writeCredentials(credentials, p)
 	if err != nil {
 		return err
 	}
 
 	fmt.Fprintln(os.Stdout, sourceHelpString(p, os.Args[0]))
 
-	err = carina.TabWriter.Flush()
-	return err
+	return carina.Printer.Flush()
 }
 
-func writeCredentials(w *tabwriter.Writer, creds *libcarina.Credentials, pth string) (err error) {
+func writeCredentials(creds *libcarina.Credentials, pth string) (err error) {
 	// TODO: Prompt when file already exists?
 	for fname, b := range creds.Files {
 		p := path.Join(pth, fname)
@@ -366,15 +770,11 @@ func writeClusterHeader(w *tabwriter.Writer) (err error) {
 	return err
 }
 
-func (app *Application) generateBashCompletion(c *kingpin.ParseContext) error {
-	app.Writer(os.Stdout)
-	if err := app.UsageForContextWithTemplate(c, 2, BashCompletionTemplate); err != nil {
-		return err
-	}
-	return nil
-}
-
 func main() {
 	app := New()
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	defer func() { _ = app.Printer.Flush() }()
+	if err := app.Root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,114 @@
+// Package rollout polls a resource until it reaches a terminal state,
+// reporting progress along the way. It replaces the ad hoc fixed-interval
+// sleep loop that used to live in carina's wait commands.
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Status classifies a polled state as pending, a success terminus, or a
+// failure terminus
+type Status int
+
+const (
+	// Pending means the resource has not yet reached a terminal state
+	Pending Status = iota
+	// Success means the resource reached a desired terminal state
+	Success
+	// Failure means the resource reached an error terminal state
+	Failure
+)
+
+// StateFunc fetches the current state of the thing being waited on
+type StateFunc func(ctx context.Context) (state string, err error)
+
+// Classifier maps a polled state to a Status
+type Classifier func(state string) Status
+
+// Progress is a single polling update sent on a Waiter's Progress channel
+type Progress struct {
+	Elapsed time.Duration
+	State   string
+	Status  Status
+}
+
+// Waiter polls a StateFunc with exponential backoff and jitter until
+// Classifier reports a terminal Status or the context passed to Wait is done.
+type Waiter struct {
+	Get      StateFunc
+	Classify Classifier
+
+	// MinBackoff/MaxBackoff bound the delay between polls
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Progress, if non-nil, receives a message after every poll. Wait sends
+	// on it without blocking, so a full channel just drops the update.
+	Progress chan<- Progress
+}
+
+// NewWaiter builds a Waiter with the package's default backoff bounds, 2s-30s
+func NewWaiter(get StateFunc, classify Classifier) *Waiter {
+	return &Waiter{
+		Get:        get,
+		Classify:   classify,
+		MinBackoff: 2 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// Wait polls Get until Classify reports Success or Failure, or ctx is done.
+// It returns the last observed state.
+func (w *Waiter) Wait(ctx context.Context) (state string, err error) {
+	start := time.Now()
+	backoff := w.MinBackoff
+
+	for {
+		state, err = w.Get(ctx)
+		if err != nil {
+			return state, err
+		}
+
+		status := w.Classify(state)
+		w.report(Progress{Elapsed: time.Since(start), State: state, Status: status})
+
+		switch status {
+		case Success:
+			return state, nil
+		case Failure:
+			return state, fmt.Errorf("reached failure state %q", state)
+		}
+
+		select {
+		case <-ctx.Done():
+			return state, ctx.Err()
+		case <-time.After(jitter(backoff)):
+		}
+
+		backoff *= 2
+		if backoff > w.MaxBackoff {
+			backoff = w.MaxBackoff
+		}
+	}
+}
+
+func (w *Waiter) report(p Progress) {
+	if w.Progress == nil {
+		return
+	}
+	select {
+	case w.Progress <- p:
+	default:
+	}
+}
+
+// jitter returns a duration in [d/2, d], so repeated pollers don't thunder
+// against the same API at the same instant
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/rackerlabs/libcarina"
+	"gopkg.in/yaml.v2"
+)
+
+// Printer renders clusters in a user-selected output format. It replaces
+// writing directly to Context.TabWriter so commands can be driven by
+// --format/-f without screen-scraping the table output.
+type Printer interface {
+	// PrintClusters prints a list of clusters, e.g. for "list"
+	PrintClusters(clusters []libcarina.Cluster) error
+	// PrintCluster prints a single cluster, e.g. for "get", "create", "grow"
+	PrintCluster(cluster *libcarina.Cluster) error
+	// Flush flushes any buffered output
+	Flush() error
+}
+
+// NewPrinter builds a Printer for a --format value: "table" (the default),
+// "json", "yaml", "template=<go template>", or "jsonpath=<jsonpath expr>".
+func NewPrinter(format string, w io.Writer) (Printer, error) {
+	switch {
+	case format == "" || format == "table":
+		return &tablePrinter{w: tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)}, nil
+
+	case format == "json":
+		return &jsonPrinter{w: w}, nil
+
+	case format == "yaml":
+		return &yamlPrinter{w: w}, nil
+
+	case strings.HasPrefix(format, "template="):
+		tmpl, err := template.New("carina").Parse(strings.TrimPrefix(format, "template="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --format template: %v", err)
+		}
+		return &templatePrinter{w: w, tmpl: tmpl}, nil
+
+	case strings.HasPrefix(format, "jsonpath="):
+		jp := jsonpath.New("carina")
+		if err := jp.Parse(strings.TrimPrefix(format, "jsonpath=")); err != nil {
+			return nil, fmt.Errorf("invalid --format jsonpath: %v", err)
+		}
+		return &jsonpathPrinter{w: w, jp: jp}, nil
+	}
+
+	return nil, fmt.Errorf("unknown --format %q, want one of table, json, yaml, template=..., jsonpath=...", format)
+}
+
+// tablePrinter is the original tabwriter-backed layout
+type tablePrinter struct {
+	w *tabwriter.Writer
+}
+
+func (p *tablePrinter) PrintClusters(clusters []libcarina.Cluster) error {
+	if err := writeClusterHeader(p.w); err != nil {
+		return err
+	}
+	for i := range clusters {
+		if err := writeCluster(p.w, &clusters[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *tablePrinter) PrintCluster(cluster *libcarina.Cluster) error {
+	return writeCluster(p.w, cluster)
+}
+
+func (p *tablePrinter) Flush() error {
+	return p.w.Flush()
+}
+
+// jsonPrinter emits one JSON document per call, indented for readability
+type jsonPrinter struct{ w io.Writer }
+
+func (p *jsonPrinter) PrintClusters(clusters []libcarina.Cluster) error {
+	return p.encode(clusters)
+}
+
+func (p *jsonPrinter) PrintCluster(cluster *libcarina.Cluster) error {
+	return p.encode(cluster)
+}
+
+func (p *jsonPrinter) encode(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p *jsonPrinter) Flush() error { return nil }
+
+// yamlPrinter emits one YAML document per call
+type yamlPrinter struct{ w io.Writer }
+
+func (p *yamlPrinter) PrintClusters(clusters []libcarina.Cluster) error {
+	return p.encode(clusters)
+}
+
+func (p *yamlPrinter) PrintCluster(cluster *libcarina.Cluster) error {
+	return p.encode(cluster)
+}
+
+func (p *yamlPrinter) encode(v interface{}) error {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = p.w.Write(b)
+	return err
+}
+
+func (p *yamlPrinter) Flush() error { return nil }
+
+// templatePrinter executes a user-supplied Go template per cluster, in the
+// style of "docker ps --format" / "kubectl get -o go-template"
+type templatePrinter struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+func (p *templatePrinter) PrintClusters(clusters []libcarina.Cluster) error {
+	for i := range clusters {
+		if err := p.PrintCluster(&clusters[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *templatePrinter) PrintCluster(cluster *libcarina.Cluster) error {
+	if err := p.tmpl.Execute(p.w, cluster); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(p.w)
+	return err
+}
+
+func (p *templatePrinter) Flush() error { return nil }
+
+// jsonpathPrinter executes a user-supplied JSONPath expression, in the style
+// of "kubectl get -o jsonpath"
+type jsonpathPrinter struct {
+	w  io.Writer
+	jp *jsonpath.JSONPath
+}
+
+func (p *jsonpathPrinter) PrintClusters(clusters []libcarina.Cluster) error {
+	return p.jp.Execute(p.w, clusters)
+}
+
+func (p *jsonpathPrinter) PrintCluster(cluster *libcarina.Cluster) error {
+	return p.jp.Execute(p.w, cluster)
+}
+
+func (p *jsonpathPrinter) Flush() error { return nil }
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+// SwarmCommand talks directly to a cluster's Docker Engine API using
+// credentials previously fetched with "carina credentials"
+type SwarmCommand struct {
+	*Command
+	ClusterName string
+	Path        string
+}
+
+// newSwarmCommand builds a "swarm <name> <cluster-name>" leaf that loads a
+// downloaded credential bundle before running
+func newSwarmCommand(ctx *Context, name, short string, run func(*SwarmCommand, *cobra.Command, []string) error) *cobra.Command {
+	sc := &SwarmCommand{Command: &Command{Context: ctx}}
+	cmd := &cobra.Command{
+		Use:   name + " <cluster-name>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			sc.ClusterName = args[0]
+			return sc.Auth(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(sc, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&sc.Path, "path", "", "path to downloaded credentials (defaults to <cluster-name>, matching \"carina credentials\")")
+	return cmd
+}
+
+// Auth sets up the cancellable Context for the command. Swarm commands talk
+// to the Docker Engine directly, so unlike Command.Auth they need no ClusterClient.
+// They also print raw Docker API objects rather than libcarina.Cluster, so
+// --format (a root PersistentFlag) doesn't apply here and is rejected rather
+// than silently ignored.
+func (carina *SwarmCommand) Auth(cmd *cobra.Command, args []string) (err error) {
+	if cmd.Flags().Changed("format") {
+		return fmt.Errorf("--format is not supported by %q, which prints Docker Engine API objects directly", cmd.CommandPath())
+	}
+	carina.setupCancellation()
+	return nil
+}
+
+// Nodes lists the nodes in the swarm
+func (carina *SwarmCommand) Nodes(cmd *cobra.Command, args []string) error {
+	cli, err := carina.dockerClient()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := cli.NodeList(carina.Ctx, types.NodeListOptions{})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(carina.TabWriter, "ID\tHOSTNAME\tROLE\tAVAILABILITY\tSTATUS")
+	for _, n := range nodes {
+		fmt.Fprintf(carina.TabWriter, "%s\t%s\t%s\t%s\t%s\n", n.ID, n.Description.Hostname, n.Spec.Role, n.Spec.Availability, n.Status.State)
+	}
+	return carina.TabWriter.Flush()
+}
+
+// Services lists the services running on the swarm
+func (carina *SwarmCommand) Services(cmd *cobra.Command, args []string) error {
+	cli, err := carina.dockerClient()
+	if err != nil {
+		return err
+	}
+
+	services, err := cli.ServiceList(carina.Ctx, types.ServiceListOptions{})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(carina.TabWriter, "ID\tNAME\tIMAGE\tREPLICAS")
+	for _, s := range services {
+		replicas := ""
+		if s.Spec.Mode.Replicated != nil && s.Spec.Mode.Replicated.Replicas != nil {
+			replicas = fmt.Sprintf("%d", *s.Spec.Mode.Replicated.Replicas)
+		}
+		fmt.Fprintf(carina.TabWriter, "%s\t%s\t%s\t%s\n", s.ID, s.Spec.Name, s.Spec.TaskTemplate.ContainerSpec.Image, replicas)
+	}
+	return carina.TabWriter.Flush()
+}
+
+// Ps lists the tasks running on the swarm
+func (carina *SwarmCommand) Ps(cmd *cobra.Command, args []string) error {
+	cli, err := carina.dockerClient()
+	if err != nil {
+		return err
+	}
+
+	tasks, err := cli.TaskList(carina.Ctx, types.TaskListOptions{})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(carina.TabWriter, "ID\tSERVICE\tIMAGE\tNODE\tDESIRED STATE\tCURRENT STATE")
+	for _, t := range tasks {
+		fmt.Fprintf(carina.TabWriter, "%s\t%s\t%s\t%s\t%s\t%s\n", t.ID, t.ServiceID, t.Spec.ContainerSpec.Image, t.NodeID, t.DesiredState, t.Status.State)
+	}
+	return carina.TabWriter.Flush()
+}
+
+// credentialsPath returns --path, falling back to <cluster-name> in the
+// current directory - the same default "carina credentials" writes to
+func (carina *SwarmCommand) credentialsPath() (string, error) {
+	if carina.Path != "" {
+		return carina.Path, nil
+	}
+	return carina.ClusterName, nil
+}
+
+// dockerClient builds a Docker Engine API client from a downloaded
+// credential bundle's ca.pem/cert.pem/key.pem and docker.env
+func (carina *SwarmCommand) dockerClient() (*client.Client, error) {
+	path, err := carina.credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := dockerHost(filepath.Join(path, "docker.env"))
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := dockerTLSConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+
+	return client.NewClient(host, "", httpClient, nil)
+}
+
+// dockerHost reads DOCKER_HOST out of a docker.env shell snippet, the same
+// file "carina credentials" writes alongside ca.pem/cert.pem/key.pem
+func dockerHost(envPath string) (string, error) {
+	f, err := os.Open(envPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "export ")
+		if !strings.HasPrefix(line, "DOCKER_HOST=") {
+			continue
+		}
+		return strings.Trim(strings.TrimPrefix(line, "DOCKER_HOST="), `"'`), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("DOCKER_HOST not found in %s", envPath)
+}
+
+// dockerTLSConfig builds a tls.Config from the ca.pem/cert.pem/key.pem
+// written by "carina credentials" into path
+func dockerTLSConfig(path string) (*tls.Config, error) {
+	caCert, err := ioutil.ReadFile(filepath.Join(path, "ca.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("unable to parse CA certificate in %s", path)
+	}
+
+	cert, err := tls.LoadX509KeyPair(filepath.Join(path, "cert.pem"), filepath.Join(path, "key.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		RootCAs:      certPool,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}